@@ -0,0 +1,216 @@
+package contracts
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// sampler 按配置的分布抽样一个整数值，目前用于 short_content 的内容长度。
+type sampler interface {
+	Sample() int
+}
+
+// constSampler 总是返回同一个值，对应历史上 `length` 固定长度的行为。
+type constSampler int
+
+func (s constSampler) Sample() int { return int(s) }
+
+// zipfSampler 在 [vmin, vmax] 范围内做偏态采样：s 越大，分布越集中在 vmin 附近，
+// 从而模拟真实内容长度里大量小内容、少量大内容的长尾分布。
+type zipfSampler struct {
+	s          float64
+	vmin, vmax int
+}
+
+func (z *zipfSampler) Sample() int {
+	span := z.vmax - z.vmin
+	if span <= 0 {
+		return z.vmin
+	}
+	// rand.Float64() 越接近 0 权重越高，用 s 次幂把质量推向 vmin。
+	weight := math.Pow(rand.Float64(), z.s)
+	return z.vmin + int(weight*float64(span))
+}
+
+// lognormalSampler 用对数正态分布采样，mu/sigma 对应下层正态分布的参数。
+type lognormalSampler struct {
+	mu, sigma  float64
+	vmin, vmax int
+}
+
+func (l *lognormalSampler) Sample() int {
+	v := int(math.Exp(l.mu + l.sigma*rand.NormFloat64()))
+	if v < l.vmin {
+		v = l.vmin
+	}
+	if l.vmax > 0 && v > l.vmax {
+		v = l.vmax
+	}
+	return v
+}
+
+// mixtureSampler 按给定权重在一组固定取值中抽样，例如
+// "64@0.7,1024@0.25,3000@0.05" 表示 70% 请求为 64 字节。
+type mixtureSampler struct {
+	values  []int
+	weights []float64
+}
+
+func (m *mixtureSampler) Sample() int {
+	r := rand.Float64()
+	acc := 0.0
+	for i, w := range m.weights {
+		acc += w
+		if r <= acc {
+			return m.values[i]
+		}
+	}
+	return m.values[len(m.values)-1]
+}
+
+// parseLengthDist 解析 length_dist 配置，格式为 "<kind>:<params>"，
+// kind 为 zipf、lognormal 或 mixture。未配置时返回 nil，调用方回退到旧行为。
+func parseLengthDist(spec string) (sampler, error) {
+	if spec == "" {
+		return nil, nil
+	}
+
+	kind, rest, ok := strings.Cut(spec, ":")
+	if !ok {
+		return nil, fmt.Errorf("invalid length_dist: %s", spec)
+	}
+
+	// mixture 的参数是 "value@weight" 列表而不是 key=value，需单独解析。
+	if kind == "mixture" {
+		return parseMixture(rest)
+	}
+
+	params, err := parseParams(rest)
+	if err != nil {
+		return nil, err
+	}
+
+	switch kind {
+	case "zipf":
+		s, err := floatParam(params, "s", 1.2)
+		if err != nil {
+			return nil, err
+		}
+		vmin, err := intParam(params, "vmin", 1)
+		if err != nil {
+			return nil, err
+		}
+		vmax, err := intParam(params, "vmax", 3000)
+		if err != nil {
+			return nil, err
+		}
+		return &zipfSampler{s: s, vmin: vmin, vmax: vmax}, nil
+
+	case "lognormal":
+		mu, err := floatParam(params, "mu", 0)
+		if err != nil {
+			return nil, err
+		}
+		sigma, err := floatParam(params, "sigma", 1)
+		if err != nil {
+			return nil, err
+		}
+		vmin, err := intParam(params, "vmin", 1)
+		if err != nil {
+			return nil, err
+		}
+		vmax, err := intParam(params, "vmax", 3000)
+		if err != nil {
+			return nil, err
+		}
+		return &lognormalSampler{mu: mu, sigma: sigma, vmin: vmin, vmax: vmax}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown length_dist kind: %s", kind)
+	}
+}
+
+// parseParams 把 "s=1.2,vmin=32,vmax=3000" 解析成 key/value 对。
+func parseParams(s string) (map[string]string, error) {
+	params := make(map[string]string)
+	if s == "" {
+		return params, nil
+	}
+
+	for _, p := range strings.Split(s, ",") {
+		kv := strings.SplitN(p, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid param: %s", p)
+		}
+		params[kv[0]] = kv[1]
+	}
+	return params, nil
+}
+
+func floatParam(params map[string]string, key string, def float64) (float64, error) {
+	v, ok := params[key]
+	if !ok {
+		return def, nil
+	}
+	return strconv.ParseFloat(v, 64)
+}
+
+func intParam(params map[string]string, key string, def int) (int, error) {
+	v, ok := params[key]
+	if !ok {
+		return def, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// parseMixture 解析 "64@0.7,1024@0.25,3000@0.05" 形式的离散混合分布。
+func parseMixture(s string) (sampler, error) {
+	entries := strings.Split(s, ",")
+	m := &mixtureSampler{
+		values:  make([]int, 0, len(entries)),
+		weights: make([]float64, 0, len(entries)),
+	}
+
+	for _, e := range entries {
+		vw := strings.SplitN(e, "@", 2)
+		if len(vw) != 2 {
+			return nil, fmt.Errorf("invalid mixture entry: %s", e)
+		}
+
+		v, err := strconv.Atoi(vw[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid mixture value: %s", e)
+		}
+		w, err := strconv.ParseFloat(vw[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid mixture weight: %s", e)
+		}
+
+		m.values = append(m.values, v)
+		m.weights = append(m.weights, w)
+	}
+
+	return m, nil
+}
+
+// cardinalitySampler 从一组 "<prefix>_<i>" 形式的固定取值中抽样，用来
+// 限定 user_id/topic 的取值基数，制造真实流量里常见的 key 碰撞模式。
+type cardinalitySampler struct {
+	prefix string
+	n      int
+}
+
+func newCardinalitySampler(prefix string, n int) *cardinalitySampler {
+	return &cardinalitySampler{prefix: prefix, n: n}
+}
+
+func (c *cardinalitySampler) Sample() string {
+	return fmt.Sprintf("%s_%d", c.prefix, rand.Intn(c.n))
+}
@@ -0,0 +1,138 @@
+package contracts
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/xuperchain/xuper-sdk-go/v2/account"
+	"github.com/xuperchain/xuper-sdk-go/v2/xuper"
+)
+
+// asset 是一个 coin-id -> amount 的转账条目。
+type asset struct {
+	id     string
+	amount uint64
+}
+
+type multiAsset struct {
+	assets       []asset
+	distribution string
+
+	client *xuper.XClient
+	config *ContractConfig
+}
+
+// NewMultiAsset 构造一个携带多资产输出的交易生成器：在同一笔 tx 里
+// 对多个 coin id 分别记账，用于压测节点的 balance-index 和 UTXO-set
+// 在开启 multi-coin 后的代码路径，单资产场景覆盖不到。
+func NewMultiAsset(config *ContractConfig, client *xuper.XClient) (Contract, error) {
+	t := &multiAsset{
+		client: client,
+		config: config,
+	}
+
+	assetsStr, ok := config.Args["assets"]
+	if !ok || assetsStr == "" {
+		return nil, fmt.Errorf("params error: multi asset assets not exist")
+	}
+
+	assets, err := parseAssets(assetsStr)
+	if err != nil {
+		return nil, fmt.Errorf("params error: %v, assets=%s", err, assetsStr)
+	}
+	t.assets = assets
+
+	switch config.Args["asset_distribution"] {
+	case "", "uniform":
+		t.distribution = "uniform"
+	case "zipf", "fixed":
+		t.distribution = config.Args["asset_distribution"]
+	default:
+		return nil, fmt.Errorf("params error: unknown asset_distribution=%s", config.Args["asset_distribution"])
+	}
+
+	return t, nil
+}
+
+func parseAssets(s string) ([]asset, error) {
+	parts := strings.Split(s, ",")
+	assets := make([]asset, 0, len(parts))
+	for _, p := range parts {
+		kv := strings.SplitN(p, ":", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid asset entry: %s", p)
+		}
+
+		amount, err := strconv.ParseUint(kv[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid asset amount: %s", p)
+		}
+
+		assets = append(assets, asset{id: kv[0], amount: amount})
+	}
+	return assets, nil
+}
+
+func (t *multiAsset) Deploy(from *account.Account, name string, code []byte, args map[string]string, opts ...xuper.RequestOption) (*xuper.Transaction, error) {
+	args = map[string]string{
+		"creator": from.Address,
+	}
+
+	return t.client.DeployWasmContract(from, name, code, args, opts...)
+}
+
+// Invoke 按配置的 asset-distribution 为每个 coin id 抽样一个金额，
+// 拼成一个 coin-id -> amount 的转账输出集合，在同一笔交易内提交。
+func (t *multiAsset) Invoke(from *account.Account, name, method string, args map[string]string, opts ...xuper.RequestOption) (*xuper.Transaction, error) {
+	amounts := make(map[string]string, len(t.assets))
+	for _, a := range t.assets {
+		amounts[a.id] = strconv.FormatUint(t.sampleAmount(a.amount), 10)
+	}
+
+	args = map[string]string{
+		"to":      from.Address,
+		"amounts": encodeAmounts(amounts),
+	}
+
+	req, err := xuper.NewInvokeContractRequest(from, "wasm", name, method, args, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return t.client.GenerateTx(req)
+}
+
+func (t *multiAsset) sampleAmount(base uint64) uint64 {
+	switch t.distribution {
+	case "fixed":
+		return base
+	case "zipf":
+		// 近似 zipf：小额转账占多数，长尾里偶尔出现接近 base 的大额转账。
+		z := rand.Float64()
+		return uint64(float64(base) / (1 + z*z*9))
+	default:
+		if base <= 1 {
+			return base
+		}
+		return uint64(rand.Int63n(int64(base))) + 1
+	}
+}
+
+// encodeAmounts 把 coin-id -> amount 编码成合约参数约定的
+// "id1:amt1,id2:amt2,..." 形式。
+func encodeAmounts(amounts map[string]string) string {
+	parts := make([]string, 0, len(amounts))
+	for id, amount := range amounts {
+		parts = append(parts, fmt.Sprintf("%s:%s", id, amount))
+	}
+	return strings.Join(parts, ",")
+}
+
+func (t *multiAsset) Query(from *account.Account, name, method string, args map[string]string, opts ...xuper.RequestOption) (*xuper.Transaction, error) {
+	return t.client.QueryWasmContract(from, name, method, args, opts...)
+}
+
+func init() {
+	RegisterContract("multi_asset", NewMultiAsset)
+}
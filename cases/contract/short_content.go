@@ -10,7 +10,11 @@ import (
 )
 
 type shortContent struct {
-	length int
+	length       int
+	lengthSampler sampler
+
+	userIDSampler *cardinalitySampler
+	topicSampler  *cardinalitySampler
 
 	client *xuper.XClient
 	config *ContractConfig
@@ -38,6 +42,30 @@ func NewShortContent(config *ContractConfig, client *xuper.XClient) (Contract, e
 		t.length = int(n)
 	}
 
+	if distSpec := config.Args["length_dist"]; distSpec != "" {
+		lengthSampler, err := parseLengthDist(distSpec)
+		if err != nil {
+			return nil, fmt.Errorf("params error: %v, length_dist=%s", err, distSpec)
+		}
+		t.lengthSampler = lengthSampler
+	}
+
+	if cardStr, ok := config.Args["user_id_cardinality"]; ok {
+		n, err := strconv.Atoi(cardStr)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("params error: invalid user_id_cardinality=%s", cardStr)
+		}
+		t.userIDSampler = newCardinalitySampler("user", n)
+	}
+
+	if cardStr, ok := config.Args["topic_cardinality"]; ok {
+		n, err := strconv.Atoi(cardStr)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("params error: invalid topic_cardinality=%s", cardStr)
+		}
+		t.topicSampler = newCardinalitySampler("topic", n)
+	}
+
 	return t, nil
 }
 
@@ -54,11 +82,26 @@ func (t *shortContent) Deploy(from *account.Account, name string, code []byte, a
 // title: string, 标题(不超过100个字符)
 // content: 具体内容(不超过3000个字符)
 func (t *shortContent) Invoke(from *account.Account, name, method string, args map[string]string, opts ...xuper.RequestOption) (*xuper.Transaction, error) {
+	length := t.length
+	if t.lengthSampler != nil {
+		length = t.lengthSampler.Sample()
+	}
+
+	userID := `xuperos`
+	if t.userIDSampler != nil {
+		userID = t.userIDSampler.Sample()
+	}
+
+	topic := from.Address
+	if t.topicSampler != nil {
+		topic = t.topicSampler.Sample()
+	}
+
 	args = map[string]string{
-		"user_id": `xuperos`,
-		"topic": from.Address,
-		"title": fmt.Sprintf("title_%d_%s", t.length, lib.RandBytes(16)),
-		"content": string(lib.RandBytes(t.length)),
+		"user_id": userID,
+		"topic": topic,
+		"title": fmt.Sprintf("title_%d_%s", length, lib.RandBytes(16)),
+		"content": string(lib.RandBytes(length)),
 	}
 	req,err:=xuper.NewInvokeContractRequest(from,"wasm",name,method,args,opts...)
 	if err!=nil{
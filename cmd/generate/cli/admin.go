@@ -0,0 +1,206 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// WorkerStat 记录单个 worker goroutine 的最新状态，供 /workers 和 /metrics 读取。
+type WorkerStat struct {
+	Count       int64  `json:"count"`
+	LastLatency int64  `json:"last_latency_ms"`
+	LastError   string `json:"last_error,omitempty"`
+}
+
+// Stats 是 Consumer 运行期间的共享状态，AdminServer 只读访问它。
+// Consumer 里每个 worker 编号只有唯一一个 goroutine 会写，因此
+// workers[i] 用 atomic.Value 存取即可做到热路径完全无锁：写者之间
+// 没有竞争，读者（HTTP handler）看到的只会是某次完整的 Store。
+type Stats struct {
+	StartedAt time.Time
+	Total     int
+
+	inc int64
+
+	workers []atomic.Value
+}
+
+func NewStats(total, concurrency int) *Stats {
+	s := &Stats{
+		StartedAt: time.Now(),
+		Total:     total,
+		workers:   make([]atomic.Value, concurrency),
+	}
+	for i := range s.workers {
+		s.workers[i].Store(WorkerStat{})
+	}
+	return s
+}
+
+func (s *Stats) recordTx(worker int, latency time.Duration, err error) {
+	atomic.AddInt64(&s.inc, 1)
+
+	w := s.workers[worker].Load().(WorkerStat)
+	w.Count++
+	w.LastLatency = latency.Milliseconds()
+	if err != nil {
+		w.LastError = err.Error()
+	}
+	s.workers[worker].Store(w)
+}
+
+func (s *Stats) snapshot() (inc int64, elapsed time.Duration, workers []WorkerStat) {
+	inc = atomic.LoadInt64(&s.inc)
+	elapsed = time.Since(s.StartedAt)
+
+	workers = make([]WorkerStat, len(s.workers))
+	for i := range s.workers {
+		workers[i] = s.workers[i].Load().(WorkerStat)
+	}
+	return
+}
+
+// AdminServer 在 Consumer 运行期间暴露进度和健康信息，替代此前只能
+// 通过 `log.Printf` 每 100000 笔打印一次的唯一可观测手段。
+type AdminServer struct {
+	addr   string
+	host   string
+	config map[string]string
+	stats  *Stats
+	mix    *WorkloadMix
+
+	server *http.Server
+}
+
+// SetMix attaches a WorkloadMix so /stats consumers can also read
+// per-contract throughput/error counters via /mix.
+func (a *AdminServer) SetMix(mix *WorkloadMix) {
+	a.mix = mix
+}
+
+func NewAdminServer(addr, host string, config map[string]string, stats *Stats) *AdminServer {
+	return &AdminServer{
+		addr:   addr,
+		host:   host,
+		config: config,
+		stats:  stats,
+	}
+}
+
+func (a *AdminServer) Start() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/stats", a.handleStats)
+	mux.HandleFunc("/workers", a.handleWorkers)
+	mux.HandleFunc("/config", a.handleConfig)
+	mux.HandleFunc("/metrics", a.handleMetrics)
+	mux.HandleFunc("/peers", a.handlePeers)
+	mux.HandleFunc("/mix", a.handleMix)
+
+	a.server = &http.Server{Addr: a.addr, Handler: mux}
+	go func() {
+		if err := a.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Printf("admin server error: %v", err)
+		}
+	}()
+	log.Printf("admin server listening on %s", a.addr)
+}
+
+func (a *AdminServer) Stop() {
+	if a.server == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	a.server.Shutdown(ctx)
+}
+
+func (a *AdminServer) handleStats(w http.ResponseWriter, r *http.Request) {
+	inc, elapsed, _ := a.stats.snapshot()
+
+	tps := float64(0)
+	if elapsed.Seconds() > 0 {
+		tps = float64(inc) / elapsed.Seconds()
+	}
+
+	eta := time.Duration(0)
+	if tps > 0 && int64(a.stats.Total) > inc {
+		eta = time.Duration(float64(int64(a.stats.Total)-inc)/tps) * time.Second
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"total":   a.stats.Total,
+		"inc":     inc,
+		"tps":     tps,
+		"elapsed": elapsed.String(),
+		"eta":     eta.String(),
+	})
+}
+
+func (a *AdminServer) handleWorkers(w http.ResponseWriter, r *http.Request) {
+	_, _, workers := a.stats.snapshot()
+	writeJSON(w, workers)
+}
+
+func (a *AdminServer) handleConfig(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, map[string]interface{}{
+		"host": a.host,
+		"args": a.config,
+	})
+}
+
+func (a *AdminServer) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	inc, elapsed, workers := a.stats.snapshot()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	fmt.Fprintf(w, "# HELP xbench_tx_total total transactions generated\n")
+	fmt.Fprintf(w, "# TYPE xbench_tx_total counter\n")
+	fmt.Fprintf(w, "xbench_tx_total %d\n", inc)
+
+	fmt.Fprintf(w, "# HELP xbench_elapsed_seconds seconds since the run started\n")
+	fmt.Fprintf(w, "# TYPE xbench_elapsed_seconds gauge\n")
+	fmt.Fprintf(w, "xbench_elapsed_seconds %f\n", elapsed.Seconds())
+
+	fmt.Fprintf(w, "# HELP xbench_worker_tx_total per-worker transaction count\n")
+	fmt.Fprintf(w, "# TYPE xbench_worker_tx_total counter\n")
+	for i, s := range workers {
+		fmt.Fprintf(w, "xbench_worker_tx_total{worker=\"%d\"} %d\n", i, s.Count)
+	}
+}
+
+// handlePeers 透传目标 xuperchain 节点的 admin_peers 接口，方便和
+// Erigon 风格的 admin_* RPC 一样在同一个地方观察对端节点情况。
+func (a *AdminServer) handlePeers(w http.ResponseWriter, r *http.Request) {
+	resp, err := http.Get(fmt.Sprintf("http://%s/admin/peers", a.host))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("peers passthrough error: %v", err), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(resp.StatusCode)
+	io.Copy(w, resp.Body)
+}
+
+// handleMix 暴露 WorkloadMix 场景下每个合约用例的吞吐/错误计数，
+// 让混合负载的运行结果也可以按用例拆开分析。
+func (a *AdminServer) handleMix(w http.ResponseWriter, r *http.Request) {
+	if a.mix == nil {
+		http.Error(w, "no scenario running", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, a.mix.Snapshot())
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("admin write response error: %v", err)
+	}
+}
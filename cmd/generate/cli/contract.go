@@ -37,6 +37,18 @@ type ContractCommand struct {
 
 	// 存证大小
 	length int
+
+	// 合约用例名称，如 short_content、multi_asset
+	contractName string
+	// multi_asset 用例参数
+	assets            string
+	assetDistribution string
+
+	// 管理/监控 HTTP 地址，为空则不启动
+	adminAddr string
+
+	// workload-mix 场景文件路径，为空则只驱动单个 --contract
+	scenario string
 }
 
 func NewContractCommand(cli *Cli) *cobra.Command {
@@ -71,8 +83,16 @@ func (t *ContractCommand) addFlags() {
 	t.cmd.Flags().StringVarP(&t.output, "output", "o", "./data/evidence", "generate tx output path")
 	t.cmd.Flags().IntVarP(&t.length, "length", "l", 200, "evidence data length")
 
+	t.cmd.Flags().StringVar(&t.contractName, "contract", "short_content", "registered contract case to drive, e.g. short_content, multi_asset")
+	t.cmd.Flags().StringVar(&t.assets, "assets", "", "multi_asset: comma separated coin-id:amount pairs, e.g. \"coin1:100,coin2:200\"")
+	t.cmd.Flags().StringVar(&t.assetDistribution, "asset-distribution", "uniform", "multi_asset: asset amount distribution, one of uniform, zipf, fixed")
+
 	t.cmd.Flags().IntVarP(&t.process, "process", "", 1, "process number")
 	t.cmd.Flags().IntVarP(&t.child, "child", "", 0, "child number")
+
+	t.cmd.Flags().StringVar(&t.adminAddr, "admin-addr", "", "admin/metrics HTTP listen address, e.g. 127.0.0.1:8090; empty disables it")
+
+	t.cmd.Flags().StringVar(&t.scenario, "scenario", "", "path (or registered name) of a workload-mix scenario file; overrides --contract")
 }
 
 func (t *ContractCommand) spawn(wg *sync.WaitGroup, child int) error {
@@ -84,6 +104,10 @@ func (t *ContractCommand) spawn(wg *sync.WaitGroup, child int) error {
 		"--concurrency", strconv.Itoa(t.concurrency),
 		"--process", "1",
 		"--child", strconv.Itoa(child),
+		"--contract", t.contractName,
+		"--assets", t.assets,
+		"--asset-distribution", t.assetDistribution,
+		"--scenario", t.scenario,
 	)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
@@ -103,10 +127,10 @@ func (t *ContractCommand) generate(ctx context.Context) error {
 		Total:       t.total,
 		Concurrency: t.concurrency,
 		Args: map[string]string{
-			"contract_name":    "short_content",
+			"contract_name":    t.contractName,
 			"contract_account": "XC1111111111111111@xuper",
 			"length":           "1024",
-			"code_path":        "data/contract/short_content.wasm",
+			"code_path":        fmt.Sprintf("data/contract/%s.wasm", t.contractName),
 			"module_name":      "wasm",
 			"method_name":      "storeShortContent",
 			"amount":           "999999",
@@ -117,10 +141,34 @@ func (t *ContractCommand) generate(ctx context.Context) error {
 		},
 	}
 
+	if t.contractName == "multi_asset" {
+		config.Args["method_name"] = "transfer"
+		config.Args["assets"] = t.assets
+		config.Args["asset_distribution"] = t.assetDistribution
+	}
+
+	var generator cases.Generator
+	var mix *WorkloadMix
+	var err error
+	outputName := t.contractName
 
-	generator, err := cases.NewContract(config)
-	if err != nil {
-		return fmt.Errorf("new evidence error: %v", err)
+	if t.scenario != "" {
+		spec, err := LoadScenario(t.scenario)
+		if err != nil {
+			return fmt.Errorf("load scenario error: %v", err)
+		}
+
+		mix, err = NewWorkloadMix(spec, config)
+		if err != nil {
+			return fmt.Errorf("new workload mix error: %v", err)
+		}
+		generator = mix
+		outputName = "mix"
+	} else {
+		generator, err = cases.NewContract(config)
+		if err != nil {
+			return fmt.Errorf("new evidence error: %v", err)
+		}
 	}
 
 	if err = generator.Init(); err != nil {
@@ -129,7 +177,7 @@ func (t *ContractCommand) generate(ctx context.Context) error {
 
 	encoders := make([]*json.Encoder, t.concurrency)
 	for i := 0; i < t.concurrency; i++ {
-		filename := fmt.Sprintf("short_content.dat.%04d", t.child*t.concurrency+i)
+		filename := fmt.Sprintf("%s.dat.%04d", outputName, t.child*t.concurrency+i)
 		file, err := os.Create(filepath.Join(t.output, filename))
 		if err != nil {
 			return fmt.Errorf("open output file error: %v", err)
@@ -139,13 +187,29 @@ func (t *ContractCommand) generate(ctx context.Context) error {
 
 	// 生成数据1.1倍冗余
 	total := int(float32(t.total/t.concurrency) * 1.1)
+
+	// stats 只在 --admin-addr 开启时才构造并传给 Consumer，避免给不需要
+	// 观测的既有用户的生成热路径增加任何额外开销。
+	var stats *Stats
+	var admin *AdminServer
+	if t.adminAddr != "" {
+		// total 是单个 worker 的配额，Stats.Total 则是全部 worker 的总量。
+		stats = NewStats(total*t.concurrency, t.concurrency)
+		admin = NewAdminServer(t.adminAddr, t.host, config.Args, stats)
+		if mix != nil {
+			admin.SetMix(mix)
+		}
+		admin.Start()
+		defer admin.Stop()
+	}
+
 	Consumer(total, t.concurrency, generator, func(i int, tx proto.Message) error {
 		if err := encoders[i].Encode(tx); err != nil {
 			log.Fatalf("write tx error: %v", err)
 			return err
 		}
 		return nil
-	})
+	}, stats)
 
 	log.Printf("child=%d, pid=%d", t.child, os.Getpid())
 	return nil
@@ -0,0 +1,311 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/xuperchain/xbench/cases"
+	"gopkg.in/yaml.v3"
+)
+
+// ScenarioPhase 描述一个压测阶段的起始时间和目标并发，用于在一次运行内
+// 做 ramp-up/ramp-down，例如 t=5m 时从 20 提升到 200 并发。
+type ScenarioPhase struct {
+	At          string `json:"at" yaml:"at"`
+	Concurrency int    `json:"concurrency" yaml:"concurrency"`
+}
+
+// ScenarioMixEntry 声明 mix 里的一个合约用例：权重，以及可选的、覆盖在
+// 共享 base 配置之上的专属参数（例如 multi_asset 的 assets）。
+type ScenarioMixEntry struct {
+	Contract string            `json:"contract" yaml:"contract"`
+	Weight   float64           `json:"weight" yaml:"weight"`
+	Args     map[string]string `json:"args,omitempty" yaml:"args,omitempty"`
+}
+
+// ScenarioSpec 是 `--scenario` 指向的 YAML/JSON 文件内容：多个已注册合约
+// 用例按权重混合，外加一段 warmup、若干 ramp 阶段和每笔交易间的 think-time。
+type ScenarioSpec struct {
+	Warmup    string             `json:"warmup" yaml:"warmup"`
+	ThinkTime string             `json:"think_time" yaml:"think_time"`
+	Mix       []ScenarioMixEntry `json:"mix" yaml:"mix"`
+	Phases    []ScenarioPhase    `json:"phases" yaml:"phases"`
+}
+
+var scenarios = make(map[string]*ScenarioSpec)
+
+// RegisterScenario 把一个内置 scenario 注册到全局表中，与 contracts 包里
+// RegisterContract 注册合约用例是同样的套路，方便不落文件就能引用常用 mix。
+func RegisterScenario(name string, spec *ScenarioSpec) {
+	scenarios[name] = spec
+}
+
+// LoadScenario 优先按名字在已注册的 scenario 里查找，找不到则把 path 当
+// 文件路径读取并按扩展名解析为 YAML 或 JSON。
+func LoadScenario(path string) (*ScenarioSpec, error) {
+	if spec, ok := scenarios[path]; ok {
+		return spec, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read scenario file error: %v", err)
+	}
+
+	spec := new(ScenarioSpec)
+	switch filepath.Ext(path) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, spec)
+	default:
+		err = json.Unmarshal(data, spec)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse scenario file error: %v", err)
+	}
+
+	return spec, nil
+}
+
+// MixCounter 记录混合场景里单个合约用例的产出和出错数量。
+type MixCounter struct {
+	Count  int64 `json:"count"`
+	Errors int64 `json:"errors"`
+}
+
+type mixEntry struct {
+	name      string
+	weight    float64
+	generator cases.Generator
+	counter   *MixCounter
+}
+
+// rampPhase is a ScenarioPhase with its At already parsed, and the list
+// kept sorted ascending by at so CurrentPhaseConcurrency can just walk it
+// in order regardless of how the scenario file declared the phases.
+type rampPhase struct {
+	at          time.Duration
+	concurrency int
+}
+
+// WorkloadMix 在多个 cases.Generator 之上按权重调度，本身也实现了
+// cases.Generator，因此可以原样交给 Consumer 驱动。
+type WorkloadMix struct {
+	entries     []mixEntry
+	totalWeight float64
+
+	warmup         time.Duration
+	thinkTime      time.Duration
+	phases         []rampPhase
+	maxConcurrency int
+
+	startedAt time.Time
+}
+
+// NewWorkloadMix 依据 scenario 里声明的权重，为每个合约用例创建一个
+// 共用 base 配置（host/total/concurrency）、但 contract_name 各异的生成器。
+func NewWorkloadMix(spec *ScenarioSpec, base *cases.Config) (*WorkloadMix, error) {
+	if len(spec.Mix) == 0 {
+		return nil, fmt.Errorf("params error: scenario mix is empty")
+	}
+
+	phases := make([]rampPhase, 0, len(spec.Phases))
+	for _, p := range spec.Phases {
+		at, err := time.ParseDuration(p.At)
+		if err != nil {
+			return nil, fmt.Errorf("params error: invalid phase at=%s", p.At)
+		}
+		phases = append(phases, rampPhase{at: at, concurrency: p.Concurrency})
+	}
+	// 按 at 升序排序，与声明顺序无关，这样 CurrentPhaseConcurrency 只要顺序
+	// 遍历覆盖 target 就能保证最终停在“已到达的阶段里 at 最大的那个”。
+	sort.Slice(phases, func(i, j int) bool { return phases[i].at < phases[j].at })
+
+	w := &WorkloadMix{
+		phases:         phases,
+		maxConcurrency: base.Concurrency,
+	}
+
+	if spec.Warmup != "" {
+		d, err := time.ParseDuration(spec.Warmup)
+		if err != nil {
+			return nil, fmt.Errorf("params error: invalid warmup=%s", spec.Warmup)
+		}
+		w.warmup = d
+	}
+
+	if spec.ThinkTime != "" {
+		d, err := time.ParseDuration(spec.ThinkTime)
+		if err != nil {
+			return nil, fmt.Errorf("params error: invalid think_time=%s", spec.ThinkTime)
+		}
+		w.thinkTime = d
+	}
+
+	for _, entry := range spec.Mix {
+		if entry.Contract == "" {
+			return nil, fmt.Errorf("params error: scenario mix entry missing contract name")
+		}
+		if entry.Weight <= 0 {
+			return nil, fmt.Errorf("params error: scenario weight for %s must be positive", entry.Contract)
+		}
+
+		config := &cases.Config{
+			Host:        base.Host,
+			Total:       base.Total,
+			Concurrency: base.Concurrency,
+			Args:        cloneArgs(base.Args),
+		}
+		config.Args["contract_name"] = entry.Contract
+		config.Args["code_path"] = fmt.Sprintf("data/contract/%s.wasm", entry.Contract)
+		config.Args["method_name"] = defaultMethodName(entry.Contract)
+		// 场景文件里声明的专属参数（例如 multi_asset 的 assets）覆盖在
+		// 共享 base 配置和上面的默认值之上，是 per-entry 配置的唯一来源。
+		for k, v := range entry.Args {
+			config.Args[k] = v
+		}
+
+		generator, err := cases.NewContract(config)
+		if err != nil {
+			return nil, fmt.Errorf("new generator for %s error: %v", entry.Contract, err)
+		}
+
+		w.entries = append(w.entries, mixEntry{
+			name:      entry.Contract,
+			weight:    entry.Weight,
+			generator: generator,
+			counter:   new(MixCounter),
+		})
+		w.totalWeight += entry.Weight
+	}
+
+	return w, nil
+}
+
+// defaultMethodName maps a registered contract case to the wasm method it
+// expects to be invoked with, mirroring the per-contract defaults that
+// ContractCommand.generate applies when driving a single case.
+func defaultMethodName(contractName string) string {
+	switch contractName {
+	case "multi_asset":
+		return "transfer"
+	default:
+		return "storeShortContent"
+	}
+}
+
+func cloneArgs(args map[string]string) map[string]string {
+	out := make(map[string]string, len(args))
+	for k, v := range args {
+		out[k] = v
+	}
+	return out
+}
+
+func (w *WorkloadMix) Init() error {
+	w.startedAt = time.Now()
+	for _, e := range w.entries {
+		if err := e.generator.Init(); err != nil {
+			return fmt.Errorf("init generator for %s error: %v", e.name, err)
+		}
+	}
+	return nil
+}
+
+// pollInterval 是 warmup/ramp 等待期间重新检查时钟的轮询间隔。
+const pollInterval = 50 * time.Millisecond
+
+// Generate 按 think-time 限速、按权重选中一个合约用例并转发给它的生成器。
+// Consumer 固定起 maxConcurrency 个 worker goroutine（编号 0..concurrency-1）；
+// ramp 通过只放行编号小于当前阶段目标并发的 worker 来模拟扩缩容——编号
+// 越界的 worker 在 generate 热路径外自旋等待，直到轮到它的阶段到来。
+// warmup 则让所有 worker 在启动后先等待一段时间再开始真正出量。
+func (w *WorkloadMix) Generate(i int) (proto.Message, error) {
+	w.waitForWarmup()
+	w.waitForPhase(i)
+
+	if w.thinkTime > 0 {
+		time.Sleep(w.thinkTime)
+	}
+
+	entry := w.pick()
+	tx, err := entry.generator.Generate(i)
+
+	if err != nil {
+		atomic.AddInt64(&entry.counter.Errors, 1)
+	} else {
+		atomic.AddInt64(&entry.counter.Count, 1)
+	}
+
+	return tx, err
+}
+
+func (w *WorkloadMix) pick() *mixEntry {
+	r := rand.Float64() * w.totalWeight
+	acc := 0.0
+	for i := range w.entries {
+		acc += w.entries[i].weight
+		if r <= acc {
+			return &w.entries[i]
+		}
+	}
+	return &w.entries[len(w.entries)-1]
+}
+
+// CurrentPhaseConcurrency 返回当前时刻（相对于启动时间）应该生效的目标
+// 并发数：w.phases 在 NewWorkloadMix 里已按 at 升序排好，所以顺序遍历、
+// 遇到还没到达的阶段就停下，取到的就是已到达的阶段里 at 最大的那个——
+// 与 scenario 文件里声明 phases 的顺序无关。未配置 phases，或还没到任何
+// 一个阶段时，回退到 --concurrency 指定的上限。
+func (w *WorkloadMix) CurrentPhaseConcurrency() int {
+	elapsed := time.Since(w.startedAt)
+	target := w.maxConcurrency
+	for _, p := range w.phases {
+		if elapsed < p.at {
+			break
+		}
+		target = p.concurrency
+	}
+	return target
+}
+
+// waitForWarmup 让 worker 在运行刚开始的 warmup 时长内原地等待，待
+// warmup 结束后对所有后续调用都是一次性的 time.Since 比较，几乎零开销。
+func (w *WorkloadMix) waitForWarmup() {
+	if w.warmup <= 0 {
+		return
+	}
+	for time.Since(w.startedAt) < w.warmup {
+		time.Sleep(pollInterval)
+	}
+}
+
+// waitForPhase 只放行编号小于当前阶段目标并发的 worker，编号越界的
+// worker 原地自旋等待，直到 ramp 阶段把目标并发提高到覆盖它为止。
+func (w *WorkloadMix) waitForPhase(worker int) {
+	if len(w.phases) == 0 {
+		return
+	}
+	for worker >= w.CurrentPhaseConcurrency() {
+		time.Sleep(pollInterval)
+	}
+}
+
+// Snapshot 返回每个合约用例当前的吞吐/错误计数，供 /stats 之外的
+// per-contract 可观测性使用。
+func (w *WorkloadMix) Snapshot() map[string]MixCounter {
+	out := make(map[string]MixCounter, len(w.entries))
+	for _, e := range w.entries {
+		out[e.name] = MixCounter{
+			Count:  atomic.LoadInt64(&e.counter.Count),
+			Errors: atomic.LoadInt64(&e.counter.Errors),
+		}
+	}
+	return out
+}
@@ -39,6 +39,9 @@ type TransactionCommand struct {
 
 	host    string
 	amount  string
+
+	// 管理/监控 HTTP 地址，为空则不启动
+	adminAddr string
 }
 
 func NewTransactionCommand(cli *Cli) *cobra.Command {
@@ -80,6 +83,8 @@ func (t *TransactionCommand) addFlags() {
 
 	t.cmd.Flags().IntVarP(&t.process, "process", "", 1, "process number")
 	t.cmd.Flags().IntVarP(&t.child, "child", "", 0, "child number")
+
+	t.cmd.Flags().StringVar(&t.adminAddr, "admin-addr", "", "admin/metrics HTTP listen address, e.g. 127.0.0.1:8090; empty disables it")
 }
 
 func (t *TransactionCommand) spawn(wg *sync.WaitGroup, child int) error {
@@ -133,20 +138,37 @@ func (t *TransactionCommand) generate(ctx context.Context) error {
 	}
 
 	total := int(float32(t.total/t.concurrency)*1.1)
+
+	// stats 只在 --admin-addr 开启时才构造并传给 Consumer，避免给不需要
+	// 观测的既有用户的生成热路径增加任何额外开销。
+	var stats *Stats
+	var admin *AdminServer
+	if t.adminAddr != "" {
+		// total 是单个 worker 的配额，Stats.Total 则是全部 worker 的总量。
+		stats = NewStats(total*t.concurrency, t.concurrency)
+		admin = NewAdminServer(t.adminAddr, t.host, config.Args, stats)
+		admin.Start()
+		defer admin.Stop()
+	}
+
 	Consumer(total, t.concurrency, generator, func(i int, tx proto.Message) error {
 		if err := encoders[i].Encode(tx); err != nil {
 			log.Fatalf("write tx error: %v", err)
 			return err
 		}
 		return nil
-	})
+	}, stats)
 
 	log.Printf("child=%d, pid=%d", t.child, os.Getpid())
 	return nil
 }
 
 type Consume func(i int, tx proto.Message) error
-func Consumer(total, concurrency int, generator cases.Generator, consume Consume) {
+
+// Consumer 驱动 concurrency 个 worker 并发生成交易。stats 可以为 nil；
+// 非 nil 时每笔交易的耗时和错误会被记录下来，供 AdminServer 的
+// /stats、/workers、/metrics 接口读取。
+func Consumer(total, concurrency int, generator cases.Generator, consume Consume, stats *Stats) {
 	var inc int64
 	wg := new(sync.WaitGroup)
 	wg.Add(concurrency)
@@ -155,13 +177,18 @@ func Consumer(total, concurrency int, generator cases.Generator, consume Consume
 			defer wg.Done()
 			var count int
 			for {
+				start := time.Now()
 				tx, err := generator.Generate(i)
 				if err != nil {
 					log.Fatalf("generate tx error: %v", err)
 					return
 				}
 
-				if err = consume(i, tx); err != nil {
+				err = consume(i, tx)
+				if stats != nil {
+					stats.recordTx(i, time.Since(start), err)
+				}
+				if err != nil {
 					return
 				}
 
@@ -0,0 +1,478 @@
+package cli
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/spf13/cobra"
+	"github.com/xuperchain/xbench/cases"
+)
+
+// VectorManifest 描述一份可重放的测试用例语料：生成器、生成参数、
+// 期望结果以及对应的数据分片，供 `record`/`run`/`diff` 三种模式共用。
+//
+// ExpectedTxHashes 按 shard 文件名分组，列表顺序与该 shard 里记录的
+// 顺序一一对应——一份 manifest 覆盖一整个语料库的所有 tx，单个标量
+// 字段放不下每笔 tx 各自的期望哈希。
+type VectorManifest struct {
+	SchemaVersion    int                 `json:"schema_version"`
+	VectorID         string              `json:"vector_id"`
+	Generator        string              `json:"generator"`
+	ConfigHash       string              `json:"config_hash"`
+	ExpectedTxHashes map[string][]string `json:"expected_tx_hashes,omitempty"`
+	ChainID          string              `json:"chain_id,omitempty"`
+	GenesisID        string              `json:"genesis_id,omitempty"`
+	Shards           []string            `json:"shards"`
+}
+
+const vectorManifestSchemaVersion = 1
+const vectorManifestFile = "manifest.json"
+
+// VectorsCommand 实现 `xbench vectors`，把既有的交易/合约生成流水线
+// 包装成一份可版本化、可回放的 conformance 语料库。
+type VectorsCommand struct {
+	cli *Cli
+	cmd *cobra.Command
+
+	generatorName string
+	host          string
+	total         int
+	concurrency   int
+	output        string
+
+	chainID   string
+	genesisID string
+
+	// 按 generator 不同而需要的默认参数
+	amount            string
+	assets            string
+	assetDistribution string
+
+	vectorsBranch string
+	vectorsPath   string
+
+	diffA string
+	diffB string
+
+	runHost        string
+	runConcurrency int
+}
+
+func NewVectorsCommand(cli *Cli) *cobra.Command {
+	t := new(VectorsCommand)
+	t.cli = cli
+	t.cmd = &cobra.Command{
+		Use:   "vectors",
+		Short: "replayable conformance test-vector corpus",
+	}
+
+	t.cmd.AddCommand(t.recordCommand())
+	t.cmd.AddCommand(t.runCommand())
+	t.cmd.AddCommand(t.diffCommand())
+
+	t.cmd.PersistentFlags().StringVar(&t.vectorsBranch, "vectors-branch", "", "pin corpus to a specific vectors branch/tag")
+	t.cmd.PersistentFlags().StringVar(&t.vectorsPath, "vectors-path", "./data/vectors", "path to the test-vector corpus")
+
+	return t.cmd
+}
+
+func (t *VectorsCommand) recordCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "record",
+		Short: "wrap a generator and record a versioned test-vector shard",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return t.record()
+		},
+	}
+	cmd.Flags().StringVar(&t.generatorName, "generator", "short_content", "generator name (transaction, short_content, multi_asset, ...)")
+	cmd.Flags().StringVar(&t.host, "host", "127.0.0.1:37101", "host to generate transaction")
+	cmd.Flags().IntVarP(&t.total, "total", "t", 1000, "total tx number")
+	cmd.Flags().IntVarP(&t.concurrency, "concurrency", "c", 1, "goroutine concurrency number")
+	cmd.Flags().StringVar(&t.chainID, "chain-id", "", "chain id the vector was recorded against")
+	cmd.Flags().StringVar(&t.genesisID, "genesis-id", "", "genesis id the vector was recorded against")
+	cmd.Flags().StringVar(&t.amount, "amount", "100000000", "transaction: init amount")
+	cmd.Flags().StringVar(&t.assets, "assets", "coin1:100,coin2:200", "multi_asset: comma separated coin-id:amount pairs")
+	cmd.Flags().StringVar(&t.assetDistribution, "asset-distribution", "uniform", "multi_asset: asset amount distribution, one of uniform, zipf, fixed")
+	return cmd
+}
+
+func (t *VectorsCommand) runCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "run",
+		Short: "replay a recorded corpus against a live xuperchain node",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return t.run()
+		},
+	}
+	cmd.Flags().StringVar(&t.runHost, "host", "127.0.0.1:37101", "target host to submit recorded txs to")
+	cmd.Flags().IntVarP(&t.runConcurrency, "concurrency", "c", 20, "replay concurrency")
+	return cmd
+}
+
+func (t *VectorsCommand) diffCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "compare two recorded corpora",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return t.diff()
+		},
+	}
+	cmd.Flags().StringVar(&t.diffA, "a", "", "path to the first corpus")
+	cmd.Flags().StringVar(&t.diffB, "b", "", "path to the second corpus")
+	return cmd
+}
+
+func (t *VectorsCommand) vectorDir() string {
+	dir := t.vectorsPath
+	if t.vectorsBranch != "" {
+		dir = filepath.Join(dir, t.vectorsBranch)
+	}
+	return dir
+}
+
+// defaultArgs 为每个 generator 填上它要求的必填参数，和 ContractCommand.generate
+// 驱动单个 contract 用例时用的是同一套默认值，这样 `vectors record` 用自己的
+// 默认 flag 跑起来就不会因为缺参数直接报错。
+func (t *VectorsCommand) defaultArgs() map[string]string {
+	if t.generatorName == "transaction" {
+		return map[string]string{
+			"amount": t.amount,
+		}
+	}
+
+	args := map[string]string{
+		"contract_name":    t.generatorName,
+		"contract_account": "XC1111111111111111@xuper",
+		"length":           "1024",
+		"code_path":        fmt.Sprintf("data/contract/%s.wasm", t.generatorName),
+		"module_name":      "wasm",
+		"method_name":      defaultMethodName(t.generatorName),
+		"amount":           "999999",
+		"user_id":          "user_id",
+		"title":            "title",
+		"topic":            "topic",
+		"content":          "content",
+	}
+
+	if t.generatorName == "multi_asset" {
+		args["assets"] = t.assets
+		args["asset_distribution"] = t.assetDistribution
+	}
+
+	return args
+}
+
+func configHash(args map[string]string) string {
+	keys := make([]string, 0, len(args))
+	for k := range args {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	for _, k := range keys {
+		fmt.Fprintf(h, "%s=%s;", k, args[k])
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+func (t *VectorsCommand) record() error {
+	dir := filepath.Join(t.vectorDir(), t.generatorName)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("create vectors dir error: %v", err)
+	}
+
+	config := &cases.Config{
+		Host:        t.host,
+		Total:       t.total,
+		Concurrency: t.concurrency,
+		Args:        t.defaultArgs(),
+	}
+
+	generator, err := newNamedGenerator(t.generatorName, config)
+	if err != nil {
+		return fmt.Errorf("new generator error: %v", err)
+	}
+
+	if err = generator.Init(); err != nil {
+		return fmt.Errorf("init generator error: %v", err)
+	}
+
+	encoders := make([]*json.Encoder, t.concurrency)
+	shards := make([]string, t.concurrency)
+	for i := 0; i < t.concurrency; i++ {
+		filename := fmt.Sprintf("%s.dat.%04d", t.generatorName, i)
+		shards[i] = filename
+		file, err := os.Create(filepath.Join(dir, filename))
+		if err != nil {
+			return fmt.Errorf("open shard file error: %v", err)
+		}
+		encoders[i] = json.NewEncoder(file)
+	}
+
+	// Consumer 的 total 参数是单个 worker 的配额，和 contract.go/transaction.go
+	// 的 generate() 一样要先按并发数摊平，否则 -t/-c 组合出来的 shard 总量会是
+	// --total 的 concurrency 倍。
+	total := int(float32(t.total/t.concurrency) * 1.1)
+
+	expectedHashes := make([][]string, t.concurrency)
+	Consumer(total, t.concurrency, generator, func(i int, tx proto.Message) error {
+		if err := encoders[i].Encode(tx); err != nil {
+			log.Fatalf("write vector error: %v", err)
+			return err
+		}
+		expectedHashes[i] = append(expectedHashes[i], txidOf(tx))
+		return nil
+	}, nil)
+
+	expectedTxHashes := make(map[string][]string, t.concurrency)
+	for i, shard := range shards {
+		expectedTxHashes[shard] = expectedHashes[i]
+	}
+
+	manifest := &VectorManifest{
+		SchemaVersion:    vectorManifestSchemaVersion,
+		VectorID:         t.generatorName + "-" + strconv.FormatInt(int64(t.total), 10),
+		Generator:        t.generatorName,
+		ConfigHash:       configHash(config.Args),
+		ExpectedTxHashes: expectedTxHashes,
+		ChainID:          t.chainID,
+		GenesisID:        t.genesisID,
+		Shards:           shards,
+	}
+
+	manifestFile, err := os.Create(filepath.Join(dir, vectorManifestFile))
+	if err != nil {
+		return fmt.Errorf("create manifest error: %v", err)
+	}
+	defer manifestFile.Close()
+
+	enc := json.NewEncoder(manifestFile)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(manifest); err != nil {
+		return fmt.Errorf("write manifest error: %v", err)
+	}
+
+	log.Printf("recorded vector=%s shards=%d dir=%s", manifest.VectorID, len(shards), dir)
+	return nil
+}
+
+// txidOf extracts the locally computed txid from a just-generated tx, the
+// same way it's already serialized into the shard file, so `run` can later
+// tell a genuine hash mismatch from the node apart from a non-deterministic
+// re-generation of the corpus.
+func txidOf(tx proto.Message) string {
+	raw, err := json.Marshal(tx)
+	if err != nil {
+		return ""
+	}
+
+	var v struct {
+		Txid string `json:"txid"`
+	}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return ""
+	}
+	return v.Txid
+}
+
+// postTxResponse is the shape `run` expects back from the target node for a
+// submitted tx: whether it was accepted, the node's own rejection reason
+// (used to tell an expected rejection from a real regression), and the txid
+// the node computed for it (used for hash-mismatch detection).
+type postTxResponse struct {
+	Txid     string `json:"txid"`
+	Error    string `json:"error,omitempty"`
+	Rejected bool   `json:"rejected,omitempty"`
+}
+
+// submitVectorTx posts one recorded tx to the target node's tx endpoint and
+// classifies the outcome against the locally recorded txid.
+func submitVectorTx(client *http.Client, host string, raw json.RawMessage, expectedHash string) (accepted, rejectedExpected, mismatched bool, err error) {
+	resp, err := client.Post(fmt.Sprintf("http://%s/v1/tx/post", host), "application/json", bytes.NewReader(raw))
+	if err != nil {
+		return false, false, false, fmt.Errorf("submit tx error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return false, false, false, fmt.Errorf("read response error: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return false, false, false, nil
+	}
+
+	var status postTxResponse
+	if err := json.Unmarshal(body, &status); err != nil {
+		return false, false, false, fmt.Errorf("decode response error: %v", err)
+	}
+
+	if status.Rejected {
+		return false, status.Error != "", false, nil
+	}
+
+	if expectedHash != "" && status.Txid != "" && status.Txid != expectedHash {
+		return false, false, true, nil
+	}
+
+	return true, false, false, nil
+}
+
+func (t *VectorsCommand) run() error {
+	dir := filepath.Join(t.vectorDir(), t.generatorName)
+	manifest, err := loadManifest(dir)
+	if err != nil {
+		return err
+	}
+
+	if t.runConcurrency <= 0 {
+		return fmt.Errorf("params error: concurrency must be positive")
+	}
+
+	// vectorJob pairs a raw recorded tx with the per-tx expected txid
+	// (manifest.ExpectedTxHashes is keyed by shard and ordered the same
+	// way the shard file is), so submitVectorTx can actually classify
+	// hash mismatches instead of always comparing against "".
+	type vectorJob struct {
+		raw      json.RawMessage
+		expected string
+	}
+
+	txCh := make(chan vectorJob, t.runConcurrency)
+	var accepted, rejectedExpected, mismatched, failed int64
+
+	var wg sync.WaitGroup
+	wg.Add(t.runConcurrency)
+	for i := 0; i < t.runConcurrency; i++ {
+		go func() {
+			defer wg.Done()
+			client := &http.Client{Timeout: 10 * time.Second}
+			for job := range txCh {
+				ok, rejExpected, mismatch, err := submitVectorTx(client, t.runHost, job.raw, job.expected)
+				switch {
+				case err != nil:
+					log.Printf("vector=%s submit error: %v", manifest.VectorID, err)
+					atomic.AddInt64(&failed, 1)
+				case ok:
+					atomic.AddInt64(&accepted, 1)
+				case rejExpected:
+					atomic.AddInt64(&rejectedExpected, 1)
+				case mismatch:
+					atomic.AddInt64(&mismatched, 1)
+				default:
+					atomic.AddInt64(&failed, 1)
+				}
+			}
+		}()
+	}
+
+	for _, shard := range manifest.Shards {
+		file, err := os.Open(filepath.Join(dir, shard))
+		if err != nil {
+			close(txCh)
+			wg.Wait()
+			return fmt.Errorf("open shard error: %v", err)
+		}
+
+		expected := manifest.ExpectedTxHashes[shard]
+		dec := json.NewDecoder(file)
+		for idx := 0; ; idx++ {
+			var raw json.RawMessage
+			if err := dec.Decode(&raw); err != nil {
+				if err != io.EOF {
+					log.Printf("vector=%s shard=%s corrupt record at index %d: %v", manifest.VectorID, shard, idx, err)
+					atomic.AddInt64(&failed, 1)
+				}
+				break
+			}
+
+			var expectedHash string
+			if idx < len(expected) {
+				expectedHash = expected[idx]
+			}
+			txCh <- vectorJob{raw: raw, expected: expectedHash}
+		}
+		file.Close()
+	}
+	close(txCh)
+	wg.Wait()
+
+	log.Printf("vector=%s accepted=%d rejected_expected=%d hash_mismatch=%d failed=%d",
+		manifest.VectorID, accepted, rejectedExpected, mismatched, failed)
+	return nil
+}
+
+func (t *VectorsCommand) diff() error {
+	if t.diffA == "" || t.diffB == "" {
+		return fmt.Errorf("params error: both --a and --b are required")
+	}
+
+	a, err := loadManifest(t.diffA)
+	if err != nil {
+		return fmt.Errorf("load corpus a error: %v", err)
+	}
+	b, err := loadManifest(t.diffB)
+	if err != nil {
+		return fmt.Errorf("load corpus b error: %v", err)
+	}
+
+	if a.ConfigHash != b.ConfigHash {
+		fmt.Printf("config_hash differs: %s != %s\n", a.ConfigHash, b.ConfigHash)
+	}
+	if len(a.ExpectedTxHashes) != len(b.ExpectedTxHashes) {
+		fmt.Printf("expected_tx_hashes shard count differs: %d != %d\n", len(a.ExpectedTxHashes), len(b.ExpectedTxHashes))
+	}
+	for shard, hashes := range a.ExpectedTxHashes {
+		if other, ok := b.ExpectedTxHashes[shard]; !ok || len(other) != len(hashes) {
+			fmt.Printf("expected_tx_hashes for shard %s differ in length\n", shard)
+		}
+	}
+	if len(a.Shards) != len(b.Shards) {
+		fmt.Printf("shard count differs: %d != %d\n", len(a.Shards), len(b.Shards))
+	}
+	return nil
+}
+
+// newNamedGenerator builds a cases.Generator by name so `vectors record` can
+// wrap any registered generator without hardcoding a single pipeline.
+func newNamedGenerator(name string, config *cases.Config) (cases.Generator, error) {
+	if name == "transaction" {
+		return cases.NewTransaction(config)
+	}
+
+	config.Args["contract_name"] = name
+	return cases.NewContract(config)
+}
+
+func loadManifest(dir string) (*VectorManifest, error) {
+	file, err := os.Open(filepath.Join(dir, vectorManifestFile))
+	if err != nil {
+		return nil, fmt.Errorf("open manifest error: %v", err)
+	}
+	defer file.Close()
+
+	manifest := new(VectorManifest)
+	if err := json.NewDecoder(file).Decode(manifest); err != nil {
+		return nil, fmt.Errorf("decode manifest error: %v", err)
+	}
+	return manifest, nil
+}
+
+func init() {
+	AddCommand(NewVectorsCommand)
+}